@@ -0,0 +1,57 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ini
+
+import "reflect"
+
+// File represents a combination of one or more INI files in memory.
+type File struct {
+	// NameMapper converts struct field name to key name if no tag is present on the field.
+	NameMapper NameMapper
+
+	// SliceDelimiter is the separator used to join/split slice field values
+	// when the field's "ini" tag doesn't specify its own via delim=.
+	// Defaults to "," when left empty.
+	SliceDelimiter string
+
+	// decoders holds user-registered decode functions for types that
+	// setWithProperType doesn't know how to handle natively, keyed by the
+	// field's reflect.Type. Populated through RegisterTypeDecoder.
+	decoders map[reflect.Type]func(*Key, reflect.Value) error
+}
+
+// sliceDelim returns the delimiter a slice field should use: the field's own
+// delim= tag option if set, else f.SliceDelimiter, else "," as the default.
+func (f *File) sliceDelim(tagDelim string) string {
+	switch {
+	case tagDelim != "":
+		return tagDelim
+	case f.SliceDelimiter != "":
+		return f.SliceDelimiter
+	default:
+		return ","
+	}
+}
+
+// RegisterTypeDecoder registers a decode function for fields of type t so
+// MapTo can populate types this package doesn't own, such as net.IP,
+// url.URL or regexp.Regexp. The most specific registration wins: a type
+// that also implements Unmarshaler is asked first.
+func (f *File) RegisterTypeDecoder(t reflect.Type, fn func(*Key, reflect.Value) error) {
+	if f.decoders == nil {
+		f.decoders = make(map[reflect.Type]func(*Key, reflect.Value) error)
+	}
+	f.decoders[t] = fn
+}
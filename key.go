@@ -0,0 +1,64 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ini
+
+import "strconv"
+
+// Uint64 returns uint64 type value.
+func (k *Key) Uint64() (uint64, error) {
+	return strconv.ParseUint(k.String(), 10, 64)
+}
+
+// Int64s returns list of int64 divided by given delimiter. Values that fail
+// to parse are reported as zero, mirroring Times' behavior of keeping the
+// result aligned with Strings(delim) element-for-element.
+func (k *Key) Int64s(delim string) []int64 {
+	vals := k.Strings(delim)
+	out := make([]int64, len(vals))
+	for i, val := range vals {
+		out[i], _ = strconv.ParseInt(val, 10, 64)
+	}
+	return out
+}
+
+// Uint64s returns list of uint64 divided by given delimiter.
+func (k *Key) Uint64s(delim string) []uint64 {
+	vals := k.Strings(delim)
+	out := make([]uint64, len(vals))
+	for i, val := range vals {
+		out[i], _ = strconv.ParseUint(val, 10, 64)
+	}
+	return out
+}
+
+// Float64s returns list of float64 divided by given delimiter.
+func (k *Key) Float64s(delim string) []float64 {
+	vals := k.Strings(delim)
+	out := make([]float64, len(vals))
+	for i, val := range vals {
+		out[i], _ = strconv.ParseFloat(val, 64)
+	}
+	return out
+}
+
+// Bools returns list of bool divided by given delimiter.
+func (k *Key) Bools(delim string) []bool {
+	vals := k.Strings(delim)
+	out := make([]bool, len(vals))
+	for i, val := range vals {
+		out[i], _ = strconv.ParseBool(val)
+	}
+	return out
+}
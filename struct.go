@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 	"unicode"
 )
@@ -56,6 +57,45 @@ var (
 	}
 )
 
+// Unmarshaler is implemented by types that can unmarshal an INI key value
+// into themselves. MapTo checks for it before falling back to
+// setWithProperType's built-in type switch, so MapTo can support types this
+// package doesn't know about natively (net.IP, url.URL, regexp.Regexp, etc.).
+type Unmarshaler interface {
+	UnmarshalINI(key *Key) error
+}
+
+// Marshaler is implemented by types that can marshal themselves into a
+// key's string value. It's the ReflectFrom counterpart to Unmarshaler.
+type Marshaler interface {
+	MarshalINI() (string, error)
+}
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// hasCustomDecoder reports whether t should bypass setWithProperType,
+// either because it implements Unmarshaler or because a decoder for it was
+// registered via File.RegisterTypeDecoder.
+func hasCustomDecoder(f *File, t reflect.Type) bool {
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return true
+	}
+	_, ok := f.decoders[t]
+	return ok
+}
+
+// decodeCustom dispatches to the Unmarshaler implementation or registered
+// decoder for field's type. Callers must have already verified one exists
+// via hasCustomDecoder.
+func (f *File) decodeCustom(key *Key, field reflect.Value) error {
+	if field.CanAddr() {
+		if u, ok := field.Addr().Interface().(Unmarshaler); ok {
+			return u.UnmarshalINI(key)
+		}
+	}
+	return f.decoders[field.Type()](key, field)
+}
+
 func (s *Section) parseFieldName(raw, actual string) string {
 	if len(actual) > 0 {
 		return actual
@@ -66,9 +106,48 @@ func (s *Section) parseFieldName(raw, actual string) string {
 	return raw
 }
 
-var reflectTime = reflect.TypeOf(time.Now()).Kind()
+// fieldOptions holds the parsed pieces of an `ini:"name,opt1,opt2=val"`
+// struct tag: the key/section name plus any trailing options.
+type fieldOptions struct {
+	Name      string
+	Delim     string
+	OmitEmpty bool
+	Default   string
+}
+
+// parseFieldOptions splits a raw "ini" tag into its name and options. The
+// name is always parts[0], even when empty (e.g. a bare ",omitempty" tag
+// falls back to NameMapper/the field's own name).
+//
+// default= consumes the rest of the tag once seen, so a value that itself
+// contains a comma (e.g. `ini:"tags,default=a,b,c"`) is kept whole instead
+// of being truncated at the first comma; because of that it must be the
+// last option in the tag. Every other option, including delim=, is a
+// single comma-delimited segment and keeps parsing after it.
+func parseFieldOptions(tag string) fieldOptions {
+	parts := strings.Split(tag, ",")
+	opts := fieldOptions{Name: parts[0]}
+	for i := 1; i < len(parts); i++ {
+		opt := parts[i]
+		switch {
+		case opt == "omitempty":
+			opts.OmitEmpty = true
+		case strings.HasPrefix(opt, "delim="):
+			opts.Delim = strings.TrimPrefix(opt, "delim=")
+		case strings.HasPrefix(opt, "default="):
+			opts.Default = strings.Join(append([]string{strings.TrimPrefix(opt, "default=")}, parts[i+1:]...), ",")
+			return opts
+		}
+	}
+	return opts
+}
+
+var (
+	reflectTime     = reflect.TypeOf(time.Now()).Kind()
+	reflectDuration = reflect.TypeOf(time.Duration(0))
+)
 
-func setWithProperType(kind reflect.Kind, key *Key, field reflect.Value) error {
+func setWithProperType(kind reflect.Kind, key *Key, field reflect.Value, delim string) error {
 	switch kind {
 	case reflect.String:
 		field.SetString(key.String())
@@ -79,16 +158,34 @@ func setWithProperType(kind reflect.Kind, key *Key, field reflect.Value) error {
 		}
 		field.SetBool(boolVal)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflectDuration {
+			durVal, err := time.ParseDuration(key.String())
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(durVal))
+			return nil
+		}
+
 		intVal, err := key.Int64()
 		if err != nil {
 			return err
 		}
 		field.SetInt(intVal)
-	case reflect.Float64:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		uintVal, err := key.Uint64()
+		if err != nil {
+			return err
+		}
+		field.SetUint(uintVal)
+	case reflect.Float32, reflect.Float64:
 		floatVal, err := key.Float64()
 		if err != nil {
 			return err
 		}
+		if kind == reflect.Float32 && field.OverflowFloat(floatVal) {
+			return fmt.Errorf("value %v overflows float32 field", floatVal)
+		}
 		field.SetFloat(floatVal)
 	case reflectTime:
 		timeVal, err := key.Time()
@@ -97,32 +194,67 @@ func setWithProperType(kind reflect.Kind, key *Key, field reflect.Value) error {
 		}
 		field.Set(reflect.ValueOf(timeVal))
 	case reflect.Slice:
-		vals := key.Strings(",")
-		numVals := len(vals)
-		if numVals == 0 {
-			return nil
-		}
+		return setSliceWithProperType(key, field, delim)
+	default:
+		return fmt.Errorf("unsupported type '%s'", kind)
+	}
+	return nil
+}
 
-		sliceOf := field.Type().Elem().Kind()
+func setSliceWithProperType(key *Key, field reflect.Value, delim string) error {
+	vals := key.Strings(delim)
+	numVals := len(vals)
+	if numVals == 0 {
+		return nil
+	}
 
-		var times []time.Time
-		if sliceOf == reflectTime {
-			times = key.Times(",")
-		}
+	elemType := field.Type().Elem()
+	sliceOf := elemType.Kind()
 
-		slice := reflect.MakeSlice(field.Type(), numVals, numVals)
+	slice := reflect.MakeSlice(field.Type(), numVals, numVals)
+	switch {
+	case sliceOf == reflectTime:
+		times := key.Times(delim)
 		for i := 0; i < numVals; i++ {
-			switch sliceOf {
-			case reflectTime:
-				slice.Index(i).Set(reflect.ValueOf(times[i]))
-			default:
-				slice.Index(i).Set(reflect.ValueOf(vals[i]))
+			slice.Index(i).Set(reflect.ValueOf(times[i]))
+		}
+	case elemType == reflectDuration:
+		for i := 0; i < numVals; i++ {
+			durVal, err := time.ParseDuration(vals[i])
+			if err != nil {
+				return err
 			}
+			slice.Index(i).SetInt(int64(durVal))
+		}
+	case sliceOf == reflect.Int, sliceOf == reflect.Int8, sliceOf == reflect.Int16, sliceOf == reflect.Int32, sliceOf == reflect.Int64:
+		ints := key.Int64s(delim)
+		for i := 0; i < numVals; i++ {
+			slice.Index(i).SetInt(ints[i])
+		}
+	case sliceOf == reflect.Uint, sliceOf == reflect.Uint8, sliceOf == reflect.Uint16, sliceOf == reflect.Uint32, sliceOf == reflect.Uint64:
+		uints := key.Uint64s(delim)
+		for i := 0; i < numVals; i++ {
+			slice.Index(i).SetUint(uints[i])
+		}
+	case sliceOf == reflect.Float32, sliceOf == reflect.Float64:
+		floats := key.Float64s(delim)
+		for i := 0; i < numVals; i++ {
+			if sliceOf == reflect.Float32 && slice.Index(i).OverflowFloat(floats[i]) {
+				return fmt.Errorf("value %v overflows float32 field", floats[i])
+			}
+			slice.Index(i).SetFloat(floats[i])
+		}
+	case sliceOf == reflect.Bool:
+		bools := key.Bools(delim)
+		for i := 0; i < numVals; i++ {
+			slice.Index(i).SetBool(bools[i])
 		}
-		field.Set(slice)
 	default:
-		return fmt.Errorf("unsupported type '%s'", kind)
+		for i := 0; i < numVals; i++ {
+			slice.Index(i).Set(reflect.ValueOf(vals[i]))
+		}
 	}
+	field.Set(slice)
 	return nil
 }
 
@@ -142,11 +274,28 @@ func (s *Section) MapTo(val reflect.Value) error {
 			continue
 		}
 
-		fieldName := s.parseFieldName(tpField.Name, tag)
+		opts := parseFieldOptions(tag)
+		fieldName := s.parseFieldName(tpField.Name, opts.Name)
 		if len(fieldName) == 0 || !field.CanSet() {
 			continue
 		}
 
+		if hasCustomDecoder(s.f, tpField.Type) {
+			key, err := s.GetKey(fieldName)
+			if err != nil {
+				if opts.Default == "" {
+					continue
+				}
+				if key, err = s.NewKey(fieldName, opts.Default); err != nil {
+					return fmt.Errorf("error mapping field(%s): %v", fieldName, err)
+				}
+			}
+			if err = s.f.decodeCustom(key, field); err != nil {
+				return fmt.Errorf("error mapping field(%s): %v", fieldName, err)
+			}
+			continue
+		}
+
 		if tpField.Type.Kind() == reflect.Struct {
 			if sec, err := s.f.GetSection(fieldName); err == nil {
 				if err = sec.MapTo(field); err != nil {
@@ -164,11 +313,18 @@ func (s *Section) MapTo(val reflect.Value) error {
 			}
 		}
 
-		if key, err := s.GetKey(fieldName); err == nil {
-			if err = setWithProperType(tpField.Type.Kind(), key, field); err != nil {
+		key, err := s.GetKey(fieldName)
+		if err != nil {
+			if opts.Default == "" {
+				continue
+			}
+			if key, err = s.NewKey(fieldName, opts.Default); err != nil {
 				return fmt.Errorf("error mapping field(%s): %v", fieldName, err)
 			}
 		}
+		if err = setWithProperType(tpField.Type.Kind(), key, field, s.f.sliceDelim(opts.Delim)); err != nil {
+			return fmt.Errorf("error mapping field(%s): %v", fieldName, err)
+		}
 	}
 	return nil
 }
@@ -201,3 +357,147 @@ func MapToWithMapper(v interface{}, mapper NameMapper, source interface{}, other
 func MapTo(v, source interface{}, others ...interface{}) error {
 	return MapToWithMapper(v, nil, source, others...)
 }
+
+// reflectWithProperType does the opposite of setWithProperType: it takes a
+// reflect.Value and turns it into the string representation that gets
+// written back into a Key.
+func reflectWithProperType(kind reflect.Kind, field reflect.Value, key *Key, delim string) error {
+	switch kind {
+	case reflect.String:
+		key.SetValue(field.String())
+	case reflect.Bool:
+		key.SetValue(fmt.Sprint(field.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflectDuration {
+			key.SetValue(time.Duration(field.Int()).String())
+			return nil
+		}
+		key.SetValue(fmt.Sprint(field.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		key.SetValue(fmt.Sprint(field.Uint()))
+	case reflect.Float32, reflect.Float64:
+		key.SetValue(fmt.Sprint(field.Float()))
+	case reflectTime:
+		key.SetValue(field.Interface().(time.Time).Format(time.RFC3339))
+	case reflect.Slice:
+		if field.Len() == 0 {
+			return nil
+		}
+
+		sliceOf := field.Type().Elem().Kind()
+		vals := make([]string, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			switch sliceOf {
+			case reflectTime:
+				vals[i] = field.Index(i).Interface().(time.Time).Format(time.RFC3339)
+			default:
+				vals[i] = fmt.Sprint(field.Index(i).Interface())
+			}
+		}
+		key.SetValue(strings.Join(vals, delim))
+	default:
+		return fmt.Errorf("unsupported type '%s'", kind)
+	}
+	return nil
+}
+
+// ReflectFrom reflects values of struct into the section.
+func (s *Section) ReflectFrom(val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		tpField := typ.Field(i)
+
+		tag := tpField.Tag.Get("ini")
+		if tag == "-" {
+			continue
+		}
+
+		opts := parseFieldOptions(tag)
+		fieldName := s.parseFieldName(tpField.Name, opts.Name)
+		if len(fieldName) == 0 || !field.CanInterface() {
+			continue
+		}
+		if opts.OmitEmpty && field.IsZero() {
+			continue
+		}
+
+		if m, ok := field.Interface().(Marshaler); ok {
+			str, err := m.MarshalINI()
+			if err != nil {
+				return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+			}
+			key, err := s.GetKey(fieldName)
+			if err != nil {
+				key, err = s.NewKey(fieldName, "")
+				if err != nil {
+					return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+				}
+			}
+			key.SetValue(str)
+			continue
+		}
+
+		if tpField.Type.Kind() == reflect.Struct && tpField.Type != reflect.TypeOf(time.Now()) {
+			sec, err := s.f.NewSection(fieldName)
+			if err != nil {
+				return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+			}
+			if err = sec.ReflectFrom(field); err != nil {
+				return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+			}
+			continue
+		} else if tpField.Type.Kind() == reflect.Ptr && tpField.Anonymous {
+			if field.IsNil() {
+				continue
+			}
+			sec, err := s.f.NewSection(fieldName)
+			if err != nil {
+				return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+			}
+			if err = sec.ReflectFrom(field); err != nil {
+				return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+			}
+			continue
+		}
+
+		key, err := s.GetKey(fieldName)
+		if err != nil {
+			key, err = s.NewKey(fieldName, "")
+			if err != nil {
+				return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+			}
+		}
+		if err = reflectWithProperType(tpField.Type.Kind(), field, key, s.f.sliceDelim(opts.Delim)); err != nil {
+			return fmt.Errorf("error reflecting field(%s): %v", fieldName, err)
+		}
+	}
+	return nil
+}
+
+// ReflectFrom reflects file from given interface{}.
+func (f *File) ReflectFrom(v interface{}) (err error) {
+	typ := reflect.TypeOf(v)
+	val := reflect.ValueOf(v)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+		val = val.Elem()
+	} else {
+		return errors.New("cannot reflect from non-pointer struct")
+	}
+
+	return f.Section("").ReflectFrom(val)
+}
+
+// ReflectFrom reflects data sources from given interface{}.
+func ReflectFrom(v interface{}) (*File, error) {
+	cfg := Empty()
+	if err := cfg.ReflectFrom(v); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2014 Unknwon
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package ini
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+type testEmbeddedStruct struct {
+	City string
+}
+
+type testReflectStruct struct {
+	Name    string
+	Tags    []string
+	Created time.Time
+	Info    testEmbeddedStruct
+}
+
+func TestReflectFromMapToRoundTrip(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	in := &testReflectStruct{
+		Name:    "gopher",
+		Tags:    []string{"a", "b", "c"},
+		Created: created,
+		Info:    testEmbeddedStruct{City: "Shanghai"},
+	}
+
+	cfg, err := ReflectFrom(in)
+	if err != nil {
+		t.Fatalf("ReflectFrom returned error: %v", err)
+	}
+
+	out := new(testReflectStruct)
+	if err = cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if out.Name != in.Name {
+		t.Errorf("Name = %q, want %q", out.Name, in.Name)
+	}
+	if len(out.Tags) != len(in.Tags) {
+		t.Fatalf("Tags = %v, want %v", out.Tags, in.Tags)
+	}
+	for i := range in.Tags {
+		if out.Tags[i] != in.Tags[i] {
+			t.Errorf("Tags[%d] = %q, want %q", i, out.Tags[i], in.Tags[i])
+		}
+	}
+	if !out.Created.Equal(in.Created) {
+		t.Errorf("Created = %v, want %v", out.Created, in.Created)
+	}
+	if out.Info.City != in.Info.City {
+		t.Errorf("Info.City = %q, want %q", out.Info.City, in.Info.City)
+	}
+}
+
+type testEmbeddedPtrStruct struct {
+	*testEmbeddedStruct
+	Name string
+}
+
+func TestReflectFromNilEmbeddedPointer(t *testing.T) {
+	in := &testEmbeddedPtrStruct{Name: "gopher"}
+
+	cfg, err := ReflectFrom(in)
+	if err != nil {
+		t.Fatalf("ReflectFrom returned error: %v", err)
+	}
+
+	out := new(testEmbeddedPtrStruct)
+	if err = cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if out.Name != in.Name {
+		t.Errorf("Name = %q, want %q", out.Name, in.Name)
+	}
+}
+
+type testNumericStruct struct {
+	Count    uint
+	Ratio    float32
+	Interval time.Duration
+}
+
+func TestReflectFromMapToRoundTripNumericTypes(t *testing.T) {
+	in := &testNumericStruct{
+		Count:    42,
+		Ratio:    3.5,
+		Interval: time.Hour,
+	}
+
+	cfg, err := ReflectFrom(in)
+	if err != nil {
+		t.Fatalf("ReflectFrom returned error: %v", err)
+	}
+
+	out := new(testNumericStruct)
+	if err = cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if out.Count != in.Count {
+		t.Errorf("Count = %d, want %d", out.Count, in.Count)
+	}
+	if out.Ratio != in.Ratio {
+		t.Errorf("Ratio = %v, want %v", out.Ratio, in.Ratio)
+	}
+	if out.Interval != in.Interval {
+		t.Errorf("Interval = %v, want %v", out.Interval, in.Interval)
+	}
+}
+
+type testNumericSliceStruct struct {
+	Ints   []int
+	Uints  []uint
+	Bools  []bool
+	Floats []float32
+}
+
+func TestReflectFromMapToRoundTripNumericSlices(t *testing.T) {
+	in := &testNumericSliceStruct{
+		Ints:   []int{-1, 0, 2},
+		Uints:  []uint{1, 2, 3},
+		Bools:  []bool{true, false, true},
+		Floats: []float32{1.5, -2.25},
+	}
+
+	cfg, err := ReflectFrom(in)
+	if err != nil {
+		t.Fatalf("ReflectFrom returned error: %v", err)
+	}
+
+	out := new(testNumericSliceStruct)
+	if err = cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if fmt.Sprint(out.Ints) != fmt.Sprint(in.Ints) {
+		t.Errorf("Ints = %v, want %v", out.Ints, in.Ints)
+	}
+	if fmt.Sprint(out.Uints) != fmt.Sprint(in.Uints) {
+		t.Errorf("Uints = %v, want %v", out.Uints, in.Uints)
+	}
+	if fmt.Sprint(out.Bools) != fmt.Sprint(in.Bools) {
+		t.Errorf("Bools = %v, want %v", out.Bools, in.Bools)
+	}
+	if fmt.Sprint(out.Floats) != fmt.Sprint(in.Floats) {
+		t.Errorf("Floats = %v, want %v", out.Floats, in.Floats)
+	}
+}
+
+type testFloat32SliceStruct struct {
+	Ratios []float32
+}
+
+func TestSetSliceWithProperTypeFloat32Overflow(t *testing.T) {
+	cfg := Empty()
+	sec := cfg.Section("")
+	if _, err := sec.NewKey("Ratios", fmt.Sprint(math.MaxFloat64)); err != nil {
+		t.Fatalf("NewKey returned error: %v", err)
+	}
+
+	out := new(testFloat32SliceStruct)
+	if err := cfg.MapTo(out); err == nil {
+		t.Fatalf("MapTo returned nil error, want an overflow error for a float32 slice")
+	}
+}
+
+// testHexColor round-trips through a non-numeric string representation to
+// exercise the Unmarshaler/Marshaler dispatch path in MapTo and
+// ReflectFrom.
+type testHexColor struct {
+	R, G, B uint8
+}
+
+func (c testHexColor) MarshalINI() (string, error) {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B), nil
+}
+
+func (c *testHexColor) UnmarshalINI(key *Key) error {
+	_, err := fmt.Sscanf(key.String(), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+type testColorStruct struct {
+	Color testHexColor
+}
+
+func TestUnmarshalerMarshalerRoundTrip(t *testing.T) {
+	in := &testColorStruct{Color: testHexColor{R: 0x1a, G: 0x2b, B: 0x3c}}
+
+	cfg, err := ReflectFrom(in)
+	if err != nil {
+		t.Fatalf("ReflectFrom returned error: %v", err)
+	}
+
+	out := new(testColorStruct)
+	if err = cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if out.Color != in.Color {
+		t.Errorf("Color = %+v, want %+v", out.Color, in.Color)
+	}
+}
+
+func TestParseFieldOptions(t *testing.T) {
+	cases := []struct {
+		tag  string
+		want fieldOptions
+	}{
+		{"Name", fieldOptions{Name: "Name"}},
+		{"name,omitempty", fieldOptions{Name: "name", OmitEmpty: true}},
+		{"tags,delim=;", fieldOptions{Name: "tags", Delim: ";"}},
+		{"tags,default=foo", fieldOptions{Name: "tags", Default: "foo"}},
+		{"tags,default=a,b,c", fieldOptions{Name: "tags", Default: "a,b,c"}},
+		{"tags,omitempty,delim=;", fieldOptions{Name: "tags", OmitEmpty: true, Delim: ";"}},
+		{"name,delim=;,omitempty,default=foo", fieldOptions{Name: "name", Delim: ";", OmitEmpty: true, Default: "foo"}},
+	}
+	for _, c := range cases {
+		got := parseFieldOptions(c.tag)
+		if got != c.want {
+			t.Errorf("parseFieldOptions(%q) = %+v, want %+v", c.tag, got, c.want)
+		}
+	}
+}
+
+type testDelimStruct struct {
+	Tags []string `ini:"tags,delim=;"`
+}
+
+func TestFieldTagOptionDelim(t *testing.T) {
+	in := &testDelimStruct{Tags: []string{"x,y", "z"}}
+
+	cfg, err := ReflectFrom(in)
+	if err != nil {
+		t.Fatalf("ReflectFrom returned error: %v", err)
+	}
+
+	out := new(testDelimStruct)
+	if err = cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if len(out.Tags) != len(in.Tags) {
+		t.Fatalf("Tags = %v, want %v", out.Tags, in.Tags)
+	}
+	for i := range in.Tags {
+		if out.Tags[i] != in.Tags[i] {
+			t.Errorf("Tags[%d] = %q, want %q (custom delimiter should not split on embedded commas)", i, out.Tags[i], in.Tags[i])
+		}
+	}
+}
+
+type testDefaultStruct struct {
+	Path string `ini:"path,default=C:\\a,b"`
+}
+
+func TestFieldTagOptionDefault(t *testing.T) {
+	// The key is never written, so MapTo must fall back to the tag's
+	// default= value, kept intact despite its embedded comma.
+	cfg := Empty()
+
+	out := new(testDefaultStruct)
+	if err := cfg.MapTo(out); err != nil {
+		t.Fatalf("MapTo returned error: %v", err)
+	}
+
+	if want := `C:\a,b`; out.Path != want {
+		t.Errorf("Path = %q, want %q (default= should not be truncated at the comma)", out.Path, want)
+	}
+}